@@ -0,0 +1,40 @@
+// Copyright (c) 2021 Contributors to the Eclipse Foundation
+//
+// See the NOTICE file(s) distributed with this work for additional
+// information regarding copyright ownership.
+//
+// This program and the accompanying materials are made available under the
+// terms of the Eclipse Public License 2.0 which is available at
+// http://www.eclipse.org/legal/epl-2.0
+//
+// SPDX-License-Identifier: EPL-2.0
+
+// Package internal provides helpers shared by the library's test suites.
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+// AssertEqual fails the test if got and want are not deeply equal.
+func AssertEqual(t *testing.T, got interface{}, want interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: '%v', want: '%v'", got, want)
+	}
+}
+
+// AssertError fails the test if got does not carry the same message as want.
+func AssertError(t *testing.T, got error, want error) {
+	t.Helper()
+	if got == nil || want == nil {
+		if got != want {
+			t.Errorf("got error: '%v', want error: '%v'", got, want)
+		}
+		return
+	}
+	if got.Error() != want.Error() {
+		t.Errorf("got error: '%v', want error: '%v'", got, want)
+	}
+}
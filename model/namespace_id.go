@@ -0,0 +1,208 @@
+// Copyright (c) 2021 Contributors to the Eclipse Foundation
+//
+// See the NOTICE file(s) distributed with this work for additional
+// information regarding copyright ownership.
+//
+// This program and the accompanying materials are made available under the
+// terms of the Eclipse Public License 2.0 which is available at
+// http://www.eclipse.org/legal/epl-2.0
+//
+// SPDX-License-Identifier: EPL-2.0
+
+package model
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxNamespacedIDLength is the maximum number of bytes a "namespace:name"
+// representation of a NamespacedID may have.
+const maxNamespacedIDLength = 256
+
+// maxNamespacedIDComponentLength is the maximum length in bytes of either the namespace or the
+// name component of a NamespacedID when encoded with MarshalBinary - one byte is used to store
+// each component's length.
+const maxNamespacedIDComponentLength = 255
+
+var _ encoding.BinaryMarshaler = (*NamespacedID)(nil)
+var _ encoding.BinaryUnmarshaler = (*NamespacedID)(nil)
+
+var namespaceRegexp = regexp.MustCompile(`^$|^[a-zA-Z_][a-zA-Z0-9_-]*(\.[a-zA-Z_][a-zA-Z0-9_-]*)*$`)
+var nameRegexp = regexp.MustCompile(`^[^/\x00-\x1F]+$`)
+
+// NamespacedID represents the namespaced entity ID structure of the Ditto entities.
+type NamespacedID struct {
+	Namespace string
+	Name      string
+}
+
+// NewNamespacedID creates a new NamespacedID instance using the provided namespace and name.
+// It returns nil if either the namespace or the name are not valid.
+func NewNamespacedID(namespace string, name string) *NamespacedID {
+	if !namespaceRegexp.MatchString(namespace) || !nameRegexp.MatchString(name) {
+		return nil
+	}
+	return &NamespacedID{
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// NewNamespacedIDFrom creates a new NamespacedID instance parsing the provided string representation
+// of a namespaced entity ID e.g. "namespace:name". It returns nil if the provided string does not
+// represent a valid namespaced entity ID.
+func NewNamespacedIDFrom(namespacedID string) *NamespacedID {
+	if len(namespacedID) == 0 || len(namespacedID) > maxNamespacedIDLength {
+		return nil
+	}
+	idx := strings.IndexByte(namespacedID, ':')
+	if idx == -1 {
+		return nil
+	}
+	return NewNamespacedID(namespacedID[:idx], namespacedID[idx+1:])
+}
+
+// NewNamespacedIDFromWithDefault creates a new NamespacedID instance parsing the provided string
+// representation of a namespaced entity ID e.g. "namespace:name". If namespacedID carries no
+// "namespace:" prefix, it is treated as a bare name and defaultNamespace is used in its place -
+// mirroring how tools such as istioctl resolve a "name" vs. "name.namespace" reference. Both the
+// inferred name and the chosen namespace are fully validated; it returns nil if either - including
+// defaultNamespace itself - is not valid.
+func NewNamespacedIDFromWithDefault(namespacedID string, defaultNamespace string) *NamespacedID {
+	if len(namespacedID) == 0 || len(namespacedID) > maxNamespacedIDLength {
+		return nil
+	}
+	idx := strings.IndexByte(namespacedID, ':')
+	if idx == -1 {
+		return NewNamespacedID(defaultNamespace, namespacedID)
+	}
+	return NewNamespacedID(namespacedID[:idx], namespacedID[idx+1:])
+}
+
+// String provides the string representation of a NamespacedID - namespace:name.
+func (entityID *NamespacedID) String() string {
+	return fmt.Sprintf("%s:%s", entityID.Namespace, entityID.Name)
+}
+
+// MarshalJSON marshals NamespacedID to its string representation namespace:name omitting the quotes.
+func (entityID *NamespacedID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(entityID.String())
+}
+
+// UnmarshalJSON unmarshal a NamespacedID from its string representation namespace:name omitting the quotes.
+func (entityID *NamespacedID) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	namespacedID := NewNamespacedIDFrom(value)
+	if namespacedID == nil {
+		return fmt.Errorf("invalid NamespacedID: %s", value)
+	}
+	entityID.Namespace = namespacedID.Namespace
+	entityID.Name = namespacedID.Name
+	return nil
+}
+
+// WithNamespace sets the provided namespace to the NamespacedID instance.
+func (entityID *NamespacedID) WithNamespace(namespace string) *NamespacedID {
+	entityID.Namespace = namespace
+	return entityID
+}
+
+// WithName sets the provided name to the NamespacedID instance.
+func (entityID *NamespacedID) WithName(name string) *NamespacedID {
+	entityID.Name = name
+	return entityID
+}
+
+// Resolve fills in the namespace of a NamespacedID that carries none - e.g. one unmarshalled from
+// a bare ":name" JSON value - with the provided defaultNamespace, leaving an already-namespaced
+// instance unchanged. It returns the instance itself, or nil - leaving the instance unchanged - if
+// the resulting namespace or name are not valid.
+func (entityID *NamespacedID) Resolve(defaultNamespace string) *NamespacedID {
+	namespace := entityID.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if NewNamespacedID(namespace, entityID.Name) == nil {
+		return nil
+	}
+	entityID.Namespace = namespace
+	return entityID
+}
+
+// MarshalBinary encodes a NamespacedID into a compact, TLS-1.2-style length-prefixed layout: one
+// byte holding the namespace's length, the namespace bytes, one byte holding the name's length, and
+// the name bytes. It is meant for embedding thing IDs in non-JSON payloads - e.g. MQTT binary
+// topics, CBOR envelopes or Hono command frames - where the "namespace:name" string representation
+// plus JSON quoting is wasteful and ambiguous once names legitimately contain ':'.
+func (entityID *NamespacedID) MarshalBinary() ([]byte, error) {
+	if NewNamespacedID(entityID.Namespace, entityID.Name) == nil {
+		return nil, fmt.Errorf("invalid NamespacedID: %s", entityID.String())
+	}
+	if len(entityID.Namespace) > maxNamespacedIDComponentLength {
+		return nil, fmt.Errorf("NamespacedID namespace exceeds %d bytes: %s", maxNamespacedIDComponentLength, entityID.Namespace)
+	}
+	if len(entityID.Name) > maxNamespacedIDComponentLength {
+		return nil, fmt.Errorf("NamespacedID name exceeds %d bytes: %s", maxNamespacedIDComponentLength, entityID.Name)
+	}
+	if len(entityID.Namespace)+1+len(entityID.Name) > maxNamespacedIDLength {
+		return nil, fmt.Errorf("NamespacedID %s:%s exceeds %d bytes", entityID.Namespace, entityID.Name, maxNamespacedIDLength)
+	}
+
+	data := make([]byte, 0, 2+len(entityID.Namespace)+len(entityID.Name))
+	data = append(data, byte(len(entityID.Namespace)))
+	data = append(data, entityID.Namespace...)
+	data = append(data, byte(len(entityID.Name)))
+	data = append(data, entityID.Name...)
+	return data, nil
+}
+
+// UnmarshalBinary decodes a NamespacedID from the layout produced by MarshalBinary. It returns a
+// descriptive error if data is truncated, if the reconstructed "namespace:name" exceeds the
+// 256-byte cap enforced by NewNamespacedIDFrom, or if the reconstructed namespace or name fails the
+// usual validation rules.
+func (entityID *NamespacedID) UnmarshalBinary(data []byte) error {
+	namespace, rest, err := readBinaryComponent(data, "namespace")
+	if err != nil {
+		return err
+	}
+	name, rest, err := readBinaryComponent(rest, "name")
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("invalid NamespacedID binary encoding: %d trailing byte(s)", len(rest))
+	}
+	if len(namespace)+1+len(name) > maxNamespacedIDLength {
+		return fmt.Errorf("invalid NamespacedID binary encoding: %s:%s exceeds %d bytes", namespace, name, maxNamespacedIDLength)
+	}
+
+	namespacedID := NewNamespacedID(namespace, name)
+	if namespacedID == nil {
+		return fmt.Errorf("invalid NamespacedID: %s:%s", namespace, name)
+	}
+	entityID.Namespace = namespacedID.Namespace
+	entityID.Name = namespacedID.Name
+	return nil
+}
+
+// readBinaryComponent reads a single length-prefixed component - as written by MarshalBinary - off
+// the front of data, returning the component, the remaining bytes and a descriptive error if data
+// is truncated.
+func readBinaryComponent(data []byte, component string) (value string, rest []byte, err error) {
+	if len(data) < 1 {
+		return "", nil, fmt.Errorf("invalid NamespacedID binary encoding: missing %s length", component)
+	}
+	length := int(data[0])
+	data = data[1:]
+	if len(data) < length {
+		return "", nil, fmt.Errorf("invalid NamespacedID binary encoding: truncated %s", component)
+	}
+	return string(data[:length]), data[length:], nil
+}
@@ -13,6 +13,7 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -163,6 +164,53 @@ func TestNamespaceIDNewNamespacedIDFrom(t *testing.T) {
 	}
 }
 
+func TestNamespaceIDNewNamespacedIDFromWithDefault(t *testing.T) {
+	tests := map[string]struct {
+		arg              string
+		defaultNamespace string
+		want             *NamespacedID
+	}{
+		"test_new_namespaced_id_from_with_default_explicit_namespace_unchanged": {
+			arg:              "test.namespace:testId",
+			defaultNamespace: "other.namespace",
+			want: &NamespacedID{
+				Namespace: "test.namespace",
+				Name:      "testId",
+			},
+		},
+		"test_new_namespaced_id_from_with_default_bare_name": {
+			arg:              "testId",
+			defaultNamespace: "test.namespace",
+			want: &NamespacedID{
+				Namespace: "test.namespace",
+				Name:      "testId",
+			},
+		},
+		"test_new_namespaced_id_from_with_default_invalid_default_namespace": {
+			arg:              "testId",
+			defaultNamespace: "test:namespace",
+			want:             nil,
+		},
+		"test_new_namespaced_id_from_with_default_invalid_name": {
+			arg:              "test/Id",
+			defaultNamespace: "test.namespace",
+			want:             nil,
+		},
+		"test_new_namespaced_id_from_with_default_empty": {
+			arg:              "",
+			defaultNamespace: "test.namespace",
+			want:             nil,
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got := NewNamespacedIDFromWithDefault(testCase.arg, testCase.defaultNamespace)
+			internal.AssertEqual(t, got, testCase.want)
+		})
+	}
+}
+
 func TestNamespaceIDString(t *testing.T) {
 	testNamespaceID := &NamespacedID{
 		Namespace: "test.namespace",
@@ -279,3 +327,162 @@ func TestNamespaceIDWithName(t *testing.T) {
 	got := testNamespace.WithName(arg)
 	internal.AssertEqual(t, got, want)
 }
+
+// repeatChar builds a string of n 'a' characters, used to exercise the binary encoding's
+// length-based boundaries independently of NewNamespacedIDFrom's own 256-byte cap.
+func repeatChar(n int) string {
+	chars := make([]byte, n)
+	for i := range chars {
+		chars[i] = 'a'
+	}
+	return string(chars)
+}
+
+func TestNamespaceIDBinaryRoundTrip(t *testing.T) {
+	maxLenName := repeatChar
+
+	tests := map[string]*NamespacedID{
+		"test_binary_round_trip_empty_namespace": {
+			Namespace: "",
+			Name:      "testId",
+		},
+		"test_binary_round_trip_name_with_colon": {
+			Namespace: "test.namespace",
+			Name:      "testId:testId",
+		},
+		"test_binary_round_trip_max_length_boundary": {
+			Namespace: maxLenName(125),
+			Name:      maxLenName(130),
+		},
+	}
+
+	for testName, testNamespaceID := range tests {
+		t.Run(testName, func(t *testing.T) {
+			data, err := testNamespaceID.MarshalBinary()
+			internal.AssertError(t, err, nil)
+
+			got := &NamespacedID{}
+			internal.AssertError(t, got.UnmarshalBinary(data), nil)
+			internal.AssertEqual(t, got, testNamespaceID)
+		})
+	}
+}
+
+func TestNamespaceIDMarshalBinaryErrors(t *testing.T) {
+	tests := map[string]struct {
+		namespacedID *NamespacedID
+		wantErr      error
+	}{
+		"test_marshal_binary_namespace_too_long": {
+			namespacedID: &NamespacedID{Namespace: repeatChar(256), Name: "testId"},
+			wantErr: fmt.Errorf("NamespacedID namespace exceeds %d bytes: %s",
+				maxNamespacedIDComponentLength, repeatChar(256)),
+		},
+		"test_marshal_binary_name_too_long": {
+			namespacedID: &NamespacedID{Namespace: "test.namespace", Name: repeatChar(256)},
+			wantErr: fmt.Errorf("NamespacedID name exceeds %d bytes: %s",
+				maxNamespacedIDComponentLength, repeatChar(256)),
+		},
+		"test_marshal_binary_combined_length_too_long": {
+			namespacedID: &NamespacedID{Namespace: repeatChar(125), Name: repeatChar(200)},
+			wantErr: fmt.Errorf("NamespacedID %s:%s exceeds %d bytes",
+				repeatChar(125), repeatChar(200), maxNamespacedIDLength),
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			data, err := testCase.namespacedID.MarshalBinary()
+			internal.AssertError(t, err, testCase.wantErr)
+			internal.AssertEqual(t, data, []byte(nil))
+		})
+	}
+}
+
+func TestNamespaceIDUnmarshalBinaryErrors(t *testing.T) {
+	tests := map[string]struct {
+		data    []byte
+		wantErr error
+	}{
+		"test_unmarshal_binary_empty": {
+			data:    []byte{},
+			wantErr: errors.New("invalid NamespacedID binary encoding: missing namespace length"),
+		},
+		"test_unmarshal_binary_truncated_namespace": {
+			data:    []byte{5, 't', 'e'},
+			wantErr: errors.New("invalid NamespacedID binary encoding: truncated namespace"),
+		},
+		"test_unmarshal_binary_missing_name_length": {
+			data:    []byte{0},
+			wantErr: errors.New("invalid NamespacedID binary encoding: missing name length"),
+		},
+		"test_unmarshal_binary_truncated_name": {
+			data:    []byte{0, 5, 't', 'e'},
+			wantErr: errors.New("invalid NamespacedID binary encoding: truncated name"),
+		},
+		"test_unmarshal_binary_trailing_bytes": {
+			data:    []byte{0, 1, 'a', 'x'},
+			wantErr: errors.New("invalid NamespacedID binary encoding: 1 trailing byte(s)"),
+		},
+		"test_unmarshal_binary_invalid_name": {
+			data:    append([]byte{0, 7}, []byte("test/Id")...),
+			wantErr: errors.New("invalid NamespacedID: :test/Id"),
+		},
+		"test_unmarshal_binary_combined_length_too_long": {
+			data: func() []byte {
+				namespace, name := repeatChar(200), repeatChar(100)
+				data := append([]byte{byte(len(namespace))}, []byte(namespace)...)
+				data = append(data, byte(len(name)))
+				data = append(data, []byte(name)...)
+				return data
+			}(),
+			wantErr: fmt.Errorf("invalid NamespacedID binary encoding: %s:%s exceeds %d bytes",
+				repeatChar(200), repeatChar(100), maxNamespacedIDLength),
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got := &NamespacedID{}
+			err := got.UnmarshalBinary(testCase.data)
+			internal.AssertError(t, err, testCase.wantErr)
+		})
+	}
+}
+
+func TestNamespaceIDResolve(t *testing.T) {
+	tests := map[string]struct {
+		testNamespaceID  *NamespacedID
+		defaultNamespace string
+		want             *NamespacedID
+	}{
+		"test_namespace_id_resolve_empty_namespace": {
+			testNamespaceID:  &NamespacedID{Name: "testId"},
+			defaultNamespace: "test.namespace",
+			want: &NamespacedID{
+				Namespace: "test.namespace",
+				Name:      "testId",
+			},
+		},
+		"test_namespace_id_resolve_existing_namespace_unchanged": {
+			testNamespaceID:  &NamespacedID{Namespace: "test.namespace", Name: "testId"},
+			defaultNamespace: "other.namespace",
+			want: &NamespacedID{
+				Namespace: "test.namespace",
+				Name:      "testId",
+			},
+		},
+		"test_namespace_id_resolve_invalid_default_namespace": {
+			testNamespaceID:  &NamespacedID{Name: "testId"},
+			defaultNamespace: "test:namespace",
+			want:             nil,
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			got := testCase.testNamespaceID.Resolve(testCase.defaultNamespace)
+			internal.AssertEqual(t, got, testCase.want)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2021 Contributors to the Eclipse Foundation
+//
+// See the NOTICE file(s) distributed with this work for additional
+// information regarding copyright ownership.
+//
+// This program and the accompanying materials are made available under the
+// terms of the Eclipse Public License 2.0 which is available at
+// http://www.eclipse.org/legal/epl-2.0
+//
+// SPDX-License-Identifier: EPL-2.0
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// poolWatchBufferSize is the buffer size of the channel backing each NamespacedIDPool.Watch
+// subscription. Events are dropped rather than blocking Add/Remove once a subscription's buffer is
+// full.
+const poolWatchBufferSize = 32
+
+// ErrDuplicateID is returned by NamespacedIDPool when the namespace:name of a NamespacedID being
+// inserted is already present in the pool.
+var ErrDuplicateID = errors.New("duplicate NamespacedID")
+
+// ErrInvalidID is returned by NamespacedIDPool when a NamespacedID being inserted is nil or does
+// not represent a valid namespace:name pair.
+var ErrInvalidID = errors.New("invalid NamespacedID")
+
+// NamespacedIDPoolEvent describes a single mutation of a NamespacedIDPool, delivered over the
+// channel of a subscription returned by its Watch method.
+type NamespacedIDPoolEvent struct {
+	// Added is true if ID was inserted into the pool, false if it was removed.
+	Added bool
+	// ID is the NamespacedID that was inserted or removed.
+	ID *NamespacedID
+}
+
+// NamespacedIDPoolSubscription is an active Watch subscription on a NamespacedIDPool. Call Close
+// once the subscription is no longer needed to stop receiving events and release its channel.
+type NamespacedIDPoolSubscription struct {
+	events chan NamespacedIDPoolEvent
+	pool   *NamespacedIDPool
+}
+
+// Events returns the channel on which this subscription receives NamespacedIDPoolEvent values.
+func (sub *NamespacedIDPoolSubscription) Events() <-chan NamespacedIDPoolEvent {
+	return sub.events
+}
+
+// Close unsubscribes from the pool and closes the underlying channel. It is safe to call more than
+// once.
+func (sub *NamespacedIDPoolSubscription) Close() {
+	sub.pool.unwatch(sub.events)
+}
+
+// NamespacedIDPool is a concurrency-safe, deduplicated collection of NamespacedID instances keyed
+// by their canonical namespace:name string representation. It is intended for client code that
+// needs one shared, validated index of thing IDs - e.g. a fleet-wide routing table or subscription
+// set - instead of re-parsing and re-validating IDs at every call site.
+type NamespacedIDPool struct {
+	mutex sync.RWMutex
+	ids   map[string]*NamespacedID
+
+	watchMu  sync.Mutex
+	watchers map[chan NamespacedIDPoolEvent]struct{}
+}
+
+// NewNamespacedIDPool creates a new NamespacedIDPool seeded with the provided NamespacedID
+// instances. It returns ErrInvalidID if any of them is nil or not a valid namespace:name pair, and
+// ErrDuplicateID if the same namespace:name appears more than once.
+func NewNamespacedIDPool(ids []*NamespacedID) (*NamespacedIDPool, error) {
+	pool := &NamespacedIDPool{
+		ids:      make(map[string]*NamespacedID, len(ids)),
+		watchers: make(map[chan NamespacedIDPoolEvent]struct{}),
+	}
+	for _, id := range ids {
+		if _, err := pool.insert(id); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// copyNamespacedID returns a defensive copy of id, so that the pool never shares a pointer the
+// caller could later mutate in place via WithNamespace/WithName.
+func copyNamespacedID(id *NamespacedID) *NamespacedID {
+	return &NamespacedID{
+		Namespace: id.Namespace,
+		Name:      id.Name,
+	}
+}
+
+// insert validates id and stores a defensive copy of it, without acquiring the mutex or notifying
+// Watch subscribers. It is used both by the constructor and, under lock, by Add. It returns the
+// stored copy so callers can use it to build the notification event.
+func (pool *NamespacedIDPool) insert(id *NamespacedID) (*NamespacedID, error) {
+	if id == nil || NewNamespacedID(id.Namespace, id.Name) == nil {
+		return nil, ErrInvalidID
+	}
+	copied := copyNamespacedID(id)
+	key := copied.String()
+	if _, exists := pool.ids[key]; exists {
+		return nil, ErrDuplicateID
+	}
+	pool.ids[key] = copied
+	return copied, nil
+}
+
+// Add inserts a defensive copy of id into the pool and notifies any Watch subscribers. It returns
+// ErrInvalidID if id is nil or not a valid namespace:name pair, and ErrDuplicateID if its
+// namespace:name is already present in the pool.
+func (pool *NamespacedIDPool) Add(id *NamespacedID) error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	copied, err := pool.insert(id)
+	if err != nil {
+		return err
+	}
+	pool.notify(NamespacedIDPoolEvent{Added: true, ID: copied})
+	return nil
+}
+
+// Remove deletes the NamespacedID identified by id's namespace:name from the pool and notifies any
+// Watch subscribers. It is a no-op if id is nil or not present in the pool.
+func (pool *NamespacedIDPool) Remove(id *NamespacedID) {
+	if id == nil {
+		return
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	key := id.String()
+	stored, exists := pool.ids[key]
+	if !exists {
+		return
+	}
+	delete(pool.ids, key)
+	pool.notify(NamespacedIDPoolEvent{Added: false, ID: stored})
+}
+
+// Get returns a defensive copy of the NamespacedID stored under the provided namespace:name key,
+// and whether it was found.
+func (pool *NamespacedIDPool) Get(namespacedID string) (*NamespacedID, bool) {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	id, ok := pool.ids[namespacedID]
+	if !ok {
+		return nil, false
+	}
+	return copyNamespacedID(id), true
+}
+
+// Contains reports whether the pool holds a NamespacedID under the provided namespace:name key.
+func (pool *NamespacedIDPool) Contains(namespacedID string) bool {
+	_, ok := pool.Get(namespacedID)
+	return ok
+}
+
+// List returns a snapshot of defensive copies of all NamespacedID instances currently stored in the
+// pool.
+func (pool *NamespacedIDPool) List() []*NamespacedID {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	list := make([]*NamespacedID, 0, len(pool.ids))
+	for _, id := range pool.ids {
+		list = append(list, copyNamespacedID(id))
+	}
+	return list
+}
+
+// Filter returns defensive copies of the NamespacedID instances in the pool that belong to the
+// provided namespace.
+func (pool *NamespacedIDPool) Filter(namespace string) []*NamespacedID {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	list := make([]*NamespacedID, 0)
+	for _, id := range pool.ids {
+		if id.Namespace == namespace {
+			list = append(list, copyNamespacedID(id))
+		}
+	}
+	return list
+}
+
+// Watch registers a new subscription that receives a NamespacedIDPoolEvent every time an entity is
+// added to or removed from the pool via Add or Remove. Each call to Watch returns an independent
+// subscription with its own buffered channel, so multiple callers - e.g. a router and a metrics
+// recorder - can watch the same pool without stealing each other's events. Call Close on the
+// returned subscription once it is no longer needed. Events are dropped rather than blocking
+// Add/Remove if a subscription's buffer is full.
+func (pool *NamespacedIDPool) Watch() *NamespacedIDPoolSubscription {
+	ch := make(chan NamespacedIDPoolEvent, poolWatchBufferSize)
+
+	pool.watchMu.Lock()
+	pool.watchers[ch] = struct{}{}
+	pool.watchMu.Unlock()
+
+	return &NamespacedIDPoolSubscription{events: ch, pool: pool}
+}
+
+// unwatch removes ch from the set of subscribers and closes it. It is safe to call more than once
+// for the same channel.
+func (pool *NamespacedIDPool) unwatch(ch chan NamespacedIDPoolEvent) {
+	pool.watchMu.Lock()
+	defer pool.watchMu.Unlock()
+
+	if _, ok := pool.watchers[ch]; !ok {
+		return
+	}
+	delete(pool.watchers, ch)
+	close(ch)
+}
+
+func (pool *NamespacedIDPool) notify(event NamespacedIDPoolEvent) {
+	pool.watchMu.Lock()
+	defer pool.watchMu.Unlock()
+
+	for ch := range pool.watchers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// MarshalJSON marshals the pool as a JSON array of its entities, each in its namespace:name string
+// representation, sorted for a deterministic result.
+func (pool *NamespacedIDPool) MarshalJSON() ([]byte, error) {
+	ids := pool.List()
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return json.Marshal(ids)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2021 Contributors to the Eclipse Foundation
+//
+// See the NOTICE file(s) distributed with this work for additional
+// information regarding copyright ownership.
+//
+// This program and the accompanying materials are made available under the
+// terms of the Eclipse Public License 2.0 which is available at
+// http://www.eclipse.org/legal/epl-2.0
+//
+// SPDX-License-Identifier: EPL-2.0
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eclipse/ditto-clients-golang/internal"
+)
+
+func TestNamespacedIDPoolNew(t *testing.T) {
+	tests := map[string]struct {
+		ids     []*NamespacedID
+		wantErr error
+	}{
+		"test_new_namespaced_id_pool_valid": {
+			ids: []*NamespacedID{
+				{Namespace: "test.namespace", Name: "testId1"},
+				{Namespace: "test.namespace", Name: "testId2"},
+			},
+			wantErr: nil,
+		},
+		"test_new_namespaced_id_pool_duplicate": {
+			ids: []*NamespacedID{
+				{Namespace: "test.namespace", Name: "testId"},
+				{Namespace: "test.namespace", Name: "testId"},
+			},
+			wantErr: ErrDuplicateID,
+		},
+		"test_new_namespaced_id_pool_invalid": {
+			ids: []*NamespacedID{
+				{Namespace: "test.namespace", Name: "test/Id"},
+			},
+			wantErr: ErrInvalidID,
+		},
+		"test_new_namespaced_id_pool_nil_entry": {
+			ids:     []*NamespacedID{nil},
+			wantErr: ErrInvalidID,
+		},
+		"test_new_namespaced_id_pool_empty": {
+			ids:     []*NamespacedID{},
+			wantErr: nil,
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pool, err := NewNamespacedIDPool(testCase.ids)
+			internal.AssertError(t, err, testCase.wantErr)
+			if testCase.wantErr == nil {
+				internal.AssertEqual(t, len(pool.List()), len(testCase.ids))
+			}
+		})
+	}
+}
+
+func TestNamespacedIDPoolAddRemove(t *testing.T) {
+	pool, err := NewNamespacedIDPool(nil)
+	internal.AssertError(t, err, nil)
+
+	testID := &NamespacedID{Namespace: "test.namespace", Name: "testId"}
+
+	internal.AssertError(t, pool.Add(testID), nil)
+	internal.AssertEqual(t, pool.Contains(testID.String()), true)
+
+	internal.AssertError(t, pool.Add(testID), ErrDuplicateID)
+	internal.AssertError(t, pool.Add(nil), ErrInvalidID)
+
+	pool.Remove(testID)
+	internal.AssertEqual(t, pool.Contains(testID.String()), false)
+
+	got, ok := pool.Get(testID.String())
+	internal.AssertEqual(t, ok, false)
+	internal.AssertEqual(t, got, (*NamespacedID)(nil))
+}
+
+func TestNamespacedIDPoolFilter(t *testing.T) {
+	pool, err := NewNamespacedIDPool([]*NamespacedID{
+		{Namespace: "test.namespace.a", Name: "testId1"},
+		{Namespace: "test.namespace.b", Name: "testId2"},
+	})
+	internal.AssertError(t, err, nil)
+
+	got := pool.Filter("test.namespace.a")
+	want := []*NamespacedID{{Namespace: "test.namespace.a", Name: "testId1"}}
+	internal.AssertEqual(t, got, want)
+}
+
+func TestNamespacedIDPoolMarshalJSON(t *testing.T) {
+	pool, err := NewNamespacedIDPool([]*NamespacedID{
+		{Namespace: "test.namespace", Name: "testId2"},
+		{Namespace: "test.namespace", Name: "testId1"},
+	})
+	internal.AssertError(t, err, nil)
+
+	got, err := pool.MarshalJSON()
+	internal.AssertError(t, err, nil)
+
+	want := []byte(`["test.namespace:testId1","test.namespace:testId2"]`)
+	internal.AssertEqual(t, got, want)
+}
+
+func TestNamespacedIDPoolWatch(t *testing.T) {
+	pool, err := NewNamespacedIDPool(nil)
+	internal.AssertError(t, err, nil)
+
+	testID := &NamespacedID{Namespace: "test.namespace", Name: "testId"}
+	sub := pool.Watch()
+	defer sub.Close()
+
+	internal.AssertError(t, pool.Add(testID), nil)
+	select {
+	case event := <-sub.Events():
+		internal.AssertEqual(t, event, NamespacedIDPoolEvent{Added: true, ID: testID})
+	case <-time.After(time.Second):
+		t.Fatal("expected an Add event on the Watch subscription")
+	}
+
+	pool.Remove(testID)
+	select {
+	case event := <-sub.Events():
+		internal.AssertEqual(t, event, NamespacedIDPoolEvent{Added: false, ID: testID})
+	case <-time.After(time.Second):
+		t.Fatal("expected a Remove event on the Watch subscription")
+	}
+}
+
+func TestNamespacedIDPoolWatchIndependentSubscribers(t *testing.T) {
+	pool, err := NewNamespacedIDPool(nil)
+	internal.AssertError(t, err, nil)
+
+	testID := &NamespacedID{Namespace: "test.namespace", Name: "testId"}
+	subA := pool.Watch()
+	defer subA.Close()
+	subB := pool.Watch()
+	defer subB.Close()
+
+	internal.AssertError(t, pool.Add(testID), nil)
+
+	for _, sub := range []*NamespacedIDPoolSubscription{subA, subB} {
+		select {
+		case event := <-sub.Events():
+			internal.AssertEqual(t, event, NamespacedIDPoolEvent{Added: true, ID: testID})
+		case <-time.After(time.Second):
+			t.Fatal("expected both subscriptions to independently receive the Add event")
+		}
+	}
+}
+
+func TestNamespacedIDPoolWatchCloseStopsDelivery(t *testing.T) {
+	pool, err := NewNamespacedIDPool(nil)
+	internal.AssertError(t, err, nil)
+
+	sub := pool.Watch()
+	sub.Close()
+	sub.Close() // closing twice must not panic
+
+	internal.AssertError(t, pool.Add(&NamespacedID{Namespace: "test.namespace", Name: "testId"}), nil)
+
+	_, open := <-sub.Events()
+	internal.AssertEqual(t, open, false)
+}
+
+func TestNamespacedIDPoolDefensiveCopy(t *testing.T) {
+	testID := &NamespacedID{Namespace: "test.namespace", Name: "testId"}
+	pool, err := NewNamespacedIDPool([]*NamespacedID{testID})
+	internal.AssertError(t, err, nil)
+
+	testID.WithNamespace("other.namespace")
+
+	internal.AssertEqual(t, pool.Contains("test.namespace:testId"), true)
+	internal.AssertEqual(t, pool.Contains("other.namespace:testId"), false)
+
+	got, ok := pool.Get("test.namespace:testId")
+	internal.AssertEqual(t, ok, true)
+
+	got.WithNamespace("yet.another.namespace")
+	stillThere, ok := pool.Get("test.namespace:testId")
+	internal.AssertEqual(t, ok, true)
+	internal.AssertEqual(t, stillThere, &NamespacedID{Namespace: "test.namespace", Name: "testId"})
+}